@@ -4,6 +4,7 @@
 package daikin
 
 import (
+	"context"
 	"crypto/tls"
 	"encoding/csv"
 	"fmt"
@@ -12,6 +13,8 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 const (
@@ -29,6 +32,8 @@ const (
 	uriGetScdlTimer    = "/aircon/get_scdltimer"
 	uriGetNotify       = "/aircon/get_notify"
 	uriSetControlInfo  = "/aircon/set_control_info"
+	uriSetProgram      = "/aircon/set_program"
+	uriSetScdlTimer    = "/aircon/set_scdltimer"
 )
 
 /*
@@ -359,12 +364,128 @@ type Daikin struct {
 	Token string
 	// Name is the human-readable name of the unit.
 	Name Name
+	// MAC is the unit's network MAC address, as reported by the Wifi
+	// adapter. It is stable across IP address changes, so it's a better
+	// key to persist than Address.
+	MAC string
+	// Firmware is the Wifi adapter's firmware version.
+	Firmware string
+	// SSID is the wireless network the unit's Wifi adapter is joined to.
+	SSID string
 	// ControlInfo contains the environment control info.
 	ControlInfo *ControlInfo
 	// SensorInfo contains the environment sensor info.
 	SensorInfo *SensorInfo
 	// WeekPower contains daily power usage data for the past 7 days
 	WeekPower *WeekPower
+	// BasicInfo contains the adapter identity and network details.
+	BasicInfo *BasicInfo
+	// ModelInfo contains the capabilities of the unit's model.
+	ModelInfo *ModelInfo
+	// Timer contains the unit's on/off timer state.
+	Timer *Timer
+	// Price contains the unit's configured electricity price.
+	Price *Price
+	// Target contains the unit's configured comfort target.
+	Target *Target
+	// YearPower contains monthly power usage data for the past 12 months.
+	YearPower *YearPower
+	// Program contains the unit's weekly schedule program state.
+	Program *Program
+	// ScheduleTimer contains the unit's schedule-linked timer state.
+	ScheduleTimer *ScheduleTimer
+	// Notify contains the unit's maintenance notification flags.
+	Notify *Notify
+
+	// streamMu guards streaming, the shared poll loop used by Stream.
+	streamMu  sync.Mutex
+	streaming *streamer
+
+	// client is the HTTP client used for all requests to the unit. It is
+	// shared across calls so the underlying Transport can reuse
+	// connections instead of paying a fresh TLS handshake every time.
+	client *http.Client
+	// clientOnce lazily initialises client for Daikin values constructed
+	// as struct literals rather than via NewClient.
+	clientOnce sync.Once
+	// retries is the number of times to retry a request that fails with
+	// a connection error or a 5xx response.
+	retries int
+	// retryBackoff is the delay between retries.
+	retryBackoff time.Duration
+	// logger, if set, receives diagnostic messages such as retries.
+	logger Logger
+}
+
+// Logger is the logging interface accepted by WithLogger. *log.Logger
+// satisfies it.
+type Logger interface {
+	Printf(format string, args ...interface{})
+}
+
+// Option configures a Daikin constructed by NewClient.
+type Option func(*Daikin)
+
+// WithTimeout sets the timeout applied to every HTTP request made to the
+// unit.
+func WithTimeout(timeout time.Duration) Option {
+	return func(d *Daikin) {
+		d.client.Timeout = timeout
+	}
+}
+
+// WithRetries sets the number of times to retry a request that fails with
+// a connection error or a 5xx response (BRP072 adapters drop connections
+// regularly), waiting backoff between attempts.
+func WithRetries(n int, backoff time.Duration) Option {
+	return func(d *Daikin) {
+		d.retries = n
+		d.retryBackoff = backoff
+	}
+}
+
+// WithHTTPClient overrides the *http.Client used for requests to the
+// unit, for callers that need custom transport behaviour.
+func WithHTTPClient(c *http.Client) Option {
+	return func(d *Daikin) {
+		d.client = c
+	}
+}
+
+// WithToken sets the authentication token some units require in the
+// X-Daikin-uuid HTTP header, switching requests to HTTPS.
+func WithToken(token string) Option {
+	return func(d *Daikin) {
+		d.Token = token
+	}
+}
+
+// WithLogger sets the logger used to report retries and other
+// diagnostics.
+func WithLogger(l Logger) Option {
+	return func(d *Daikin) {
+		d.logger = l
+	}
+}
+
+// NewClient creates a Daikin configured to talk to the unit at addr (host
+// or host:port), applying any Options given. The returned *Daikin reuses
+// a single http.Transport across requests, avoiding the per-request TLS
+// handshake cost of constructing a new client every call.
+func NewClient(addr string, opts ...Option) *Daikin {
+	d := &Daikin{
+		Address: addr,
+		client: &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			},
+		},
+	}
+	d.clientOnce.Do(func() {})
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
 }
 
 // SensorInfo represents current sensor values.
@@ -526,7 +647,13 @@ func (d *Daikin) parseResponse(resp *http.Response) (map[string]string, error) {
 	if err != nil {
 		return nil, err
 	}
-	r := csv.NewReader(strings.NewReader(string(body)))
+	return parseValues(string(body))
+}
+
+// parseValues parses a Daikin comma-separated key=value reply, as returned
+// by both the HTTP aircon API and the UDP discovery probe.
+func parseValues(body string) (map[string]string, error) {
+	r := csv.NewReader(strings.NewReader(body))
 	records, err := r.ReadAll()
 	if err != nil {
 		return nil, err
@@ -541,13 +668,17 @@ func (d *Daikin) parseResponse(resp *http.Response) (map[string]string, error) {
 		values[parts[0]] = parts[1]
 	}
 	return values, nil
-
 }
 
-// Set configures the current setting to the unit.
+// SetControlInfo configures the current setting to the unit.
 func (d *Daikin) SetControlInfo() error {
+	return d.SetControlInfoContext(context.Background())
+}
+
+// SetControlInfoContext is SetControlInfo with a caller-supplied context.
+func (d *Daikin) SetControlInfoContext(ctx context.Context) error {
 	qStr := d.ControlInfo.urlValues()
-	resp, err := d.httpGet(fmt.Sprintf("%s?%s", uriSetControlInfo, qStr.Encode()))
+	resp, err := d.httpGet(ctx, fmt.Sprintf("%s?%s", uriSetControlInfo, qStr.Encode()))
 	if err != nil {
 		return err
 	}
@@ -563,7 +694,12 @@ func (d *Daikin) SetControlInfo() error {
 
 // GetControlInfo gets the current control settings for the unit.
 func (d *Daikin) GetControlInfo() error {
-	resp, err := d.httpGet(uriGetControlInfo)
+	return d.GetControlInfoContext(context.Background())
+}
+
+// GetControlInfoContext is GetControlInfo with a caller-supplied context.
+func (d *Daikin) GetControlInfoContext(ctx context.Context) error {
+	resp, err := d.httpGet(ctx, uriGetControlInfo)
 	if err != nil {
 		return err
 	}
@@ -575,35 +711,81 @@ func (d *Daikin) GetControlInfo() error {
 	return d.ControlInfo.populate(vals)
 }
 
-func (d *Daikin) httpGet(path string) (*http.Response, error) {
+// ensureClient lazily initialises client for Daikin values built as struct
+// literals (eg by Discover) rather than via NewClient.
+func (d *Daikin) ensureClient() {
+	d.clientOnce.Do(func() {
+		if d.client == nil {
+			d.client = &http.Client{
+				Transport: &http.Transport{
+					TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+				},
+			}
+		}
+	})
+}
+
+// httpGet issues a GET request against path on the unit, retrying on
+// connection errors and 5xx responses according to d.retries/retryBackoff.
+func (d *Daikin) httpGet(ctx context.Context, path string) (*http.Response, error) {
+	d.ensureClient()
+
 	var scheme string
 	if d.Token == "" {
 		scheme = "http"
 	} else {
 		scheme = "https"
 	}
-	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s://%s%s", scheme, d.Address, path), nil)
-	if d.Token != "" {
-		request.Header["X-Daikin-uuid"] = []string{d.Token}
-	}
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-	}
+	url := fmt.Sprintf("%s://%s%s", scheme, d.Address, path)
 
-	client := &http.Client{Transport: tr}
-	resp, err := client.Do(request)
-	if err != nil {
-		return resp, err
-	}
-	if resp.StatusCode != 200 {
-		return resp, fmt.Errorf("GET %s request failed: %d", path, resp.StatusCode)
+	var resp *http.Response
+	var err error
+	for attempt := 0; attempt <= d.retries; attempt++ {
+		if attempt > 0 {
+			if d.logger != nil {
+				d.logger.Printf("daikin: retrying GET %s (attempt %d) after error: %v", path, attempt, err)
+			}
+			select {
+			case <-time.After(d.retryBackoff):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		var req *http.Request
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("building request for %s: %v", path, err)
+		}
+		if d.Token != "" {
+			req.Header.Set("X-Daikin-uuid", d.Token)
+		}
+
+		resp, err = d.client.Do(req)
+		if err != nil {
+			continue
+		}
+		if resp.StatusCode >= 500 {
+			resp.Body.Close()
+			err = fmt.Errorf("GET %s request failed: %d", path, resp.StatusCode)
+			continue
+		}
+		if resp.StatusCode != 200 {
+			return resp, fmt.Errorf("GET %s request failed: %d", path, resp.StatusCode)
+		}
+		return resp, nil
 	}
 	return resp, err
 }
 
 // GetSensorInfo gets the current sensor values for the unit.
 func (d *Daikin) GetSensorInfo() error {
-	resp, err := d.httpGet(uriGetSensorInfo)
+	return d.GetSensorInfoContext(context.Background())
+}
+
+// GetSensorInfoContext is GetSensorInfo with a caller-supplied context.
+func (d *Daikin) GetSensorInfoContext(ctx context.Context) error {
+	resp, err := d.httpGet(ctx, uriGetSensorInfo)
 	if err != nil {
 		return err
 	}
@@ -615,8 +797,14 @@ func (d *Daikin) GetSensorInfo() error {
 	return d.SensorInfo.populate(vals)
 }
 
+// GetWeekPower gets the unit's power usage for the past 7 days.
 func (d *Daikin) GetWeekPower() error {
-	resp, err := d.httpGet(uriGetWeekPower)
+	return d.GetWeekPowerContext(context.Background())
+}
+
+// GetWeekPowerContext is GetWeekPower with a caller-supplied context.
+func (d *Daikin) GetWeekPowerContext(ctx context.Context) error {
+	resp, err := d.httpGet(ctx, uriGetWeekPower)
 	if err != nil {
 		return err
 	}
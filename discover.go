@@ -0,0 +1,152 @@
+package daikin
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+const (
+	// discoveryPort is the UDP port Daikin Wifi adapters listen on for
+	// discovery probes.
+	discoveryPort = 30050
+	// discoveryProbe is the broadcast payload adapters respond to with
+	// their /common/basic_info data.
+	discoveryProbe = "DAIKIN_UDP/common/basic_info"
+)
+
+// DiscoverOption configures the behaviour of Discover.
+type DiscoverOption func(*discoverConfig)
+
+type discoverConfig struct {
+	iface string
+}
+
+// WithInterface binds the discovery broadcast to the named network
+// interface. This is needed on multi-homed hosts where the default route
+// isn't the interface connected to the Daikin units.
+func WithInterface(name string) DiscoverOption {
+	return func(c *discoverConfig) {
+		c.iface = name
+	}
+}
+
+// Discover broadcasts a UDP probe on port 30050 and collects replies from
+// any Daikin Wifi adapters on the local network, until timeout elapses or
+// ctx is cancelled. Units are deduplicated by MAC address and returned as
+// Daikin structs pre-populated from /common/basic_info (Address, Name,
+// MAC, Firmware, SSID), ready for GetControlInfo/GetSensorInfo calls.
+func Discover(ctx context.Context, timeout time.Duration, opts ...DiscoverOption) ([]*Daikin, error) {
+	cfg := discoverConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	laddr, err := discoverLocalAddr(cfg.iface)
+	if err != nil {
+		return nil, fmt.Errorf("discover: %v", err)
+	}
+
+	conn, err := net.ListenUDP("udp4", laddr)
+	if err != nil {
+		return nil, fmt.Errorf("discover: %v", err)
+	}
+	defer conn.Close()
+
+	broadcast := &net.UDPAddr{IP: net.IPv4bcast, Port: discoveryPort}
+	if _, err := conn.WriteTo([]byte(discoveryProbe), broadcast); err != nil {
+		return nil, fmt.Errorf("discover: broadcast: %v", err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	if dl, ok := ctx.Deadline(); ok && dl.Before(deadline) {
+		deadline = dl
+	}
+	if err := conn.SetReadDeadline(deadline); err != nil {
+		return nil, fmt.Errorf("discover: %v", err)
+	}
+
+	// Cut a blocked read short when ctx is cancelled.
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.SetReadDeadline(time.Now())
+		case <-stop:
+		}
+	}()
+
+	seen := map[string]*Daikin{}
+	buf := make([]byte, 2048)
+	for {
+		select {
+		case <-ctx.Done():
+			return unitsFromSeen(seen), nil
+		default:
+		}
+
+		n, addr, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Timeout() {
+				return unitsFromSeen(seen), nil
+			}
+			return unitsFromSeen(seen), err
+		}
+
+		values, err := parseValues(string(buf[:n]))
+		if err != nil {
+			continue
+		}
+		mac := values["mac"]
+		if mac == "" {
+			continue
+		}
+		if _, ok := seen[mac]; ok {
+			continue
+		}
+		d := &Daikin{
+			Address:  addr.IP.String(),
+			MAC:      mac,
+			Firmware: values["ver"],
+			SSID:     values["ssid"],
+		}
+		if name, ok := values["name"]; ok {
+			d.Name.decode(name)
+		}
+		seen[mac] = d
+	}
+}
+
+func unitsFromSeen(seen map[string]*Daikin) []*Daikin {
+	units := make([]*Daikin, 0, len(seen))
+	for _, d := range seen {
+		units = append(units, d)
+	}
+	return units
+}
+
+// discoverLocalAddr resolves the UDP address to bind the discovery socket
+// to. An empty iface binds to all interfaces.
+func discoverLocalAddr(iface string) (*net.UDPAddr, error) {
+	if iface == "" {
+		return &net.UDPAddr{Port: 0}, nil
+	}
+	ifi, err := net.InterfaceByName(iface)
+	if err != nil {
+		return nil, fmt.Errorf("interface %s: %v", iface, err)
+	}
+	addrs, err := ifi.Addrs()
+	if err != nil {
+		return nil, fmt.Errorf("interface %s: %v", iface, err)
+	}
+	for _, a := range addrs {
+		ipnet, ok := a.(*net.IPNet)
+		if !ok || ipnet.IP.To4() == nil {
+			continue
+		}
+		return &net.UDPAddr{IP: ipnet.IP, Port: 0}, nil
+	}
+	return nil, fmt.Errorf("interface %s has no IPv4 address", iface)
+}
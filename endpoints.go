@@ -0,0 +1,551 @@
+package daikin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// BasicInfo represents the adapter identity and network details returned
+// by /common/basic_info, as used during Discover.
+type BasicInfo struct {
+	// Name is the human-readable name of the unit.
+	Name Name
+	// MAC is the Wifi adapter's MAC address. It is stable across IP
+	// address changes, so it's a better key to persist than Address.
+	MAC string
+	// SSID is the wireless network the adapter is joined to.
+	SSID string
+	// Firmware is the Wifi adapter's firmware version.
+	Firmware string
+	// AdapterType identifies the kind of Wifi adapter fitted (eg "aircon").
+	AdapterType string
+	// Power is the current power status of the unit.
+	Power Power
+}
+
+// ret=OK,type=aircon,reg=au,dst=1,ver=2_8_0,rev=0,pow=1,err=0,location=0,
+// name=Lounge,icon=0,method=home only,port=30050,id=,pw=,lpw_flag=0,
+// adp_kind=3,pv=3,cpv=3,cpv_minor=00,led=1,en_setzone=1,
+// mac=000000000000,adp_mode=run,en_hol=0,ssid=MyWifi,grp_name=,en_grp=0
+func (b *BasicInfo) populate(values map[string]string) error {
+	for k, v := range values {
+		var err error
+		switch k {
+		case "name":
+			err = b.Name.decode(v)
+		case "mac":
+			b.MAC = v
+		case "ssid":
+			b.SSID = v
+		case "ver":
+			b.Firmware = v
+		case "type":
+			b.AdapterType = v
+		case "pow":
+			err = b.Power.decode(v)
+		case "ret":
+			if v != returnOk {
+				err = fmt.Errorf("device returned error ret=%s", v)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *BasicInfo) String() string {
+	return fmt.Sprintf("name: %s\nmac: %s\nssid: %s\nfirmware: %s\nadapter_type: %s\n",
+		b.Name.String(), b.MAC, b.SSID, b.Firmware, b.AdapterType)
+}
+
+// GetBasicInfo gets the adapter identity and network details for the unit.
+func (d *Daikin) GetBasicInfo() error {
+	return d.GetBasicInfoContext(context.Background())
+}
+
+// GetBasicInfoContext is GetBasicInfo with a caller-supplied context.
+func (d *Daikin) GetBasicInfoContext(ctx context.Context) error {
+	resp, err := d.httpGet(ctx, uriGetBasicInfo)
+	if err != nil {
+		return err
+	}
+	d.BasicInfo = &BasicInfo{}
+	vals, err := d.parseResponse(resp)
+	if err != nil {
+		return fmt.Errorf("GetBasicInfo: %v", err)
+	}
+	if err := d.BasicInfo.populate(vals); err != nil {
+		return err
+	}
+
+	// Discover populates these same fields from the basic_info broadcast
+	// reply; do it here too so a Daikin built directly via NewClient has a
+	// MAC/Firmware/SSID to key off once it's called GetBasicInfo.
+	d.Name = d.BasicInfo.Name
+	d.MAC = d.BasicInfo.MAC
+	d.Firmware = d.BasicInfo.Firmware
+	d.SSID = d.BasicInfo.SSID
+	return nil
+}
+
+// ModelInfo represents the capabilities of the unit's model, as returned
+// by /aircon/get_model_info.
+type ModelInfo struct {
+	// Model is the model identifier reported by the unit.
+	Model string
+	// SupportsHumidity reports whether the unit can report/set humidity.
+	SupportsHumidity bool
+	// SupportsFanRate reports whether the unit's fan speed can be set.
+	SupportsFanRate bool
+	// SupportsFanDir reports whether the unit's louvre direction can be
+	// set.
+	SupportsFanDir bool
+}
+
+// ret=OK,model=0,type=N,humd=0,s_humd=0,en_humd=0,en_frate=1,en_fdir=1,
+// en_rtemp_a=0,en_spmode=0,en_ipw_sep=0,en_scdltimer=1
+func (m *ModelInfo) populate(values map[string]string) error {
+	for k, v := range values {
+		var err error
+		switch k {
+		case "model":
+			m.Model = v
+		case "en_humd":
+			m.SupportsHumidity = v == "1"
+		case "en_frate":
+			m.SupportsFanRate = v == "1"
+		case "en_fdir":
+			m.SupportsFanDir = v == "1"
+		case "ret":
+			if v != returnOk {
+				err = fmt.Errorf("device returned error ret=%s", v)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *ModelInfo) String() string {
+	return fmt.Sprintf("model: %s\nsupports_humidity: %v\nsupports_fan_rate: %v\nsupports_fan_dir: %v\n",
+		m.Model, m.SupportsHumidity, m.SupportsFanRate, m.SupportsFanDir)
+}
+
+// GetModelInfo gets the capabilities of the unit's model.
+func (d *Daikin) GetModelInfo() error {
+	return d.GetModelInfoContext(context.Background())
+}
+
+// GetModelInfoContext is GetModelInfo with a caller-supplied context.
+func (d *Daikin) GetModelInfoContext(ctx context.Context) error {
+	resp, err := d.httpGet(ctx, uriGetModelInfo)
+	if err != nil {
+		return err
+	}
+	d.ModelInfo = &ModelInfo{}
+	vals, err := d.parseResponse(resp)
+	if err != nil {
+		return fmt.Errorf("GetModelInfo: %v", err)
+	}
+	return d.ModelInfo.populate(vals)
+}
+
+// Timer represents the unit's on/off timer state, as returned by
+// /aircon/get_timer.
+type Timer struct {
+	// OnTimerSet reports whether an on-timer is currently armed.
+	OnTimerSet bool
+	// OffTimerSet reports whether an off-timer is currently armed.
+	OffTimerSet bool
+}
+
+// ret=OK,sta=0,stm=0
+func (t *Timer) populate(values map[string]string) error {
+	for k, v := range values {
+		var err error
+		switch k {
+		case "sta":
+			t.OnTimerSet = v == "1"
+		case "stm":
+			t.OffTimerSet = v == "1"
+		case "ret":
+			if v != returnOk {
+				err = fmt.Errorf("device returned error ret=%s", v)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *Timer) String() string {
+	return fmt.Sprintf("on_timer_set: %v\noff_timer_set: %v\n", t.OnTimerSet, t.OffTimerSet)
+}
+
+// GetTimer gets the unit's on/off timer state.
+func (d *Daikin) GetTimer() error {
+	return d.GetTimerContext(context.Background())
+}
+
+// GetTimerContext is GetTimer with a caller-supplied context.
+func (d *Daikin) GetTimerContext(ctx context.Context) error {
+	resp, err := d.httpGet(ctx, uriGetTimer)
+	if err != nil {
+		return err
+	}
+	d.Timer = &Timer{}
+	vals, err := d.parseResponse(resp)
+	if err != nil {
+		return fmt.Errorf("GetTimer: %v", err)
+	}
+	return d.Timer.populate(vals)
+}
+
+// Price represents the configured electricity price used by the unit to
+// estimate running costs, as returned by /aircon/get_price.
+type Price struct {
+	// PricePerKWh is the configured energy cost per kWh.
+	PricePerKWh float64
+}
+
+// ret=OK,price_int=00000,price_dec=00
+func (p *Price) populate(values map[string]string) error {
+	for k, v := range values {
+		var err error
+		switch k {
+		case "price_int", "price_dec":
+			// price_int/price_dec together form a fixed point price; the
+			// unit only ever exposes them separately, so combine them on
+			// whichever arrives.
+			val, perr := parsePrice(values["price_int"], values["price_dec"])
+			if perr != nil {
+				err = perr
+			} else {
+				p.PricePerKWh = val
+			}
+		case "ret":
+			if v != returnOk {
+				err = fmt.Errorf("device returned error ret=%s", v)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parsePrice(intPart, decPart string) (float64, error) {
+	if intPart == "" || decPart == "" {
+		return 0, nil
+	}
+	var i, d int
+	if _, err := fmt.Sscanf(intPart, "%d", &i); err != nil {
+		return 0, fmt.Errorf("error parsing price_int=%s: %v", intPart, err)
+	}
+	if _, err := fmt.Sscanf(decPart, "%d", &d); err != nil {
+		return 0, fmt.Errorf("error parsing price_dec=%s: %v", decPart, err)
+	}
+	return float64(i) + float64(d)/100, nil
+}
+
+func (p *Price) String() string {
+	return fmt.Sprintf("price_per_kwh: %.2f\n", p.PricePerKWh)
+}
+
+// GetPrice gets the unit's configured electricity price.
+func (d *Daikin) GetPrice() error {
+	return d.GetPriceContext(context.Background())
+}
+
+// GetPriceContext is GetPrice with a caller-supplied context.
+func (d *Daikin) GetPriceContext(ctx context.Context) error {
+	resp, err := d.httpGet(ctx, uriGetPrice)
+	if err != nil {
+		return err
+	}
+	d.Price = &Price{}
+	vals, err := d.parseResponse(resp)
+	if err != nil {
+		return fmt.Errorf("GetPrice: %v", err)
+	}
+	return d.Price.populate(vals)
+}
+
+// Target represents the unit's configured comfort target, as returned by
+// /aircon/get_target.
+type Target struct {
+	// Level is the configured target comfort level, 0-100.
+	Level int
+}
+
+// ret=OK,target=0
+func (t *Target) populate(values map[string]string) error {
+	for k, v := range values {
+		var err error
+		switch k {
+		case "target":
+			var val int
+			if _, serr := fmt.Sscanf(v, "%d", &val); serr != nil {
+				err = fmt.Errorf("error parsing target=%s: %v", v, serr)
+			} else {
+				t.Level = val
+			}
+		case "ret":
+			if v != returnOk {
+				err = fmt.Errorf("device returned error ret=%s", v)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *Target) String() string {
+	return fmt.Sprintf("target: %d\n", t.Level)
+}
+
+// GetTarget gets the unit's configured comfort target.
+func (d *Daikin) GetTarget() error {
+	return d.GetTargetContext(context.Background())
+}
+
+// GetTargetContext is GetTarget with a caller-supplied context.
+func (d *Daikin) GetTargetContext(ctx context.Context) error {
+	resp, err := d.httpGet(ctx, uriGetTarget)
+	if err != nil {
+		return err
+	}
+	d.Target = &Target{}
+	vals, err := d.parseResponse(resp)
+	if err != nil {
+		return fmt.Errorf("GetTarget: %v", err)
+	}
+	return d.Target.populate(vals)
+}
+
+// YearPower represents power usage over the past 12 months.
+type YearPower struct {
+	// WattHours holds one entry per month, oldest first: WattHours[0] is
+	// 11 months ago, WattHours[11] is the current month.
+	WattHours [12]WattHours
+}
+
+// ret=OK,curr_year_heat=0,datas=1200/2300/3400/1800/900/400/300/200/600/1300/2200/3100
+func (y *YearPower) populate(values map[string]string) error {
+	for k, v := range values {
+		var err error
+		switch k {
+		case "datas":
+			elems := strings.Split(v, "/")
+			if len(elems) != 12 {
+				return fmt.Errorf("expected 12 elements in year power data, got %d", len(elems))
+			}
+			for i, e := range elems {
+				if err := y.WattHours[i].decode(e); err != nil {
+					return err
+				}
+			}
+		case "ret":
+			if v != returnOk {
+				err = fmt.Errorf("device returned error ret=%s", v)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (y *YearPower) String() string {
+	s := "watt_hours:"
+	for _, w := range y.WattHours {
+		s += " " + w.String()
+	}
+	return s + "\n"
+}
+
+// GetYearPower gets the unit's power usage for the past 12 months.
+func (d *Daikin) GetYearPower() error {
+	return d.GetYearPowerContext(context.Background())
+}
+
+// GetYearPowerContext is GetYearPower with a caller-supplied context.
+func (d *Daikin) GetYearPowerContext(ctx context.Context) error {
+	resp, err := d.httpGet(ctx, uriGetYearPower)
+	if err != nil {
+		return err
+	}
+	d.YearPower = &YearPower{}
+	vals, err := d.parseResponse(resp)
+	if err != nil {
+		return fmt.Errorf("GetYearPower: %v", err)
+	}
+	return d.YearPower.populate(vals)
+}
+
+// Program represents the unit's weekly schedule program state, as returned
+// by /aircon/get_program.
+type Program struct {
+	// Enabled reports whether a weekly program is currently active.
+	Enabled bool
+	// Raw is the raw, device-encoded schedule data (the "f=" field).
+	Raw string
+}
+
+// ret=OK,en_scdl=1,f=...
+func (p *Program) populate(values map[string]string) error {
+	for k, v := range values {
+		var err error
+		switch k {
+		case "en_scdl":
+			p.Enabled = v == "1"
+		case "f":
+			p.Raw = v
+		case "ret":
+			if v != returnOk {
+				err = fmt.Errorf("device returned error ret=%s", v)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Program) String() string {
+	return fmt.Sprintf("enabled: %v\nraw: %s\n", p.Enabled, p.Raw)
+}
+
+// GetProgram gets the unit's weekly schedule program state.
+func (d *Daikin) GetProgram() error {
+	return d.GetProgramContext(context.Background())
+}
+
+// GetProgramContext is GetProgram with a caller-supplied context.
+func (d *Daikin) GetProgramContext(ctx context.Context) error {
+	resp, err := d.httpGet(ctx, uriGetProgram)
+	if err != nil {
+		return err
+	}
+	d.Program = &Program{}
+	vals, err := d.parseResponse(resp)
+	if err != nil {
+		return fmt.Errorf("GetProgram: %v", err)
+	}
+	return d.Program.populate(vals)
+}
+
+// ScheduleTimer represents the unit's schedule-linked timer state, as
+// returned by /aircon/get_scdltimer.
+type ScheduleTimer struct {
+	// Enabled reports whether the schedule timer is currently active.
+	Enabled bool
+	// Raw is the raw, device-encoded timer data.
+	Raw string
+}
+
+// ret=OK,en_scdltimer=1,tt=...
+func (s *ScheduleTimer) populate(values map[string]string) error {
+	for k, v := range values {
+		var err error
+		switch k {
+		case "en_scdltimer":
+			s.Enabled = v == "1"
+		case "tt":
+			s.Raw = v
+		case "ret":
+			if v != returnOk {
+				err = fmt.Errorf("device returned error ret=%s", v)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *ScheduleTimer) String() string {
+	return fmt.Sprintf("enabled: %v\nraw: %s\n", s.Enabled, s.Raw)
+}
+
+// GetScheduleTimer gets the unit's schedule-linked timer state.
+func (d *Daikin) GetScheduleTimer() error {
+	return d.GetScheduleTimerContext(context.Background())
+}
+
+// GetScheduleTimerContext is GetScheduleTimer with a caller-supplied
+// context.
+func (d *Daikin) GetScheduleTimerContext(ctx context.Context) error {
+	resp, err := d.httpGet(ctx, uriGetScdlTimer)
+	if err != nil {
+		return err
+	}
+	d.ScheduleTimer = &ScheduleTimer{}
+	vals, err := d.parseResponse(resp)
+	if err != nil {
+		return fmt.Errorf("GetScheduleTimer: %v", err)
+	}
+	return d.ScheduleTimer.populate(vals)
+}
+
+// Notify represents the unit's maintenance notification flags, as returned
+// by /aircon/get_notify.
+type Notify struct {
+	// FilterSignOn reports whether the air filter needs cleaning.
+	FilterSignOn bool
+}
+
+// ret=OK,filter_sign=0
+func (n *Notify) populate(values map[string]string) error {
+	for k, v := range values {
+		var err error
+		switch k {
+		case "filter_sign":
+			n.FilterSignOn = v == "1"
+		case "ret":
+			if v != returnOk {
+				err = fmt.Errorf("device returned error ret=%s", v)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *Notify) String() string {
+	return fmt.Sprintf("filter_sign_on: %v\n", n.FilterSignOn)
+}
+
+// GetNotify gets the unit's maintenance notification flags.
+func (d *Daikin) GetNotify() error {
+	return d.GetNotifyContext(context.Background())
+}
+
+// GetNotifyContext is GetNotify with a caller-supplied context.
+func (d *Daikin) GetNotifyContext(ctx context.Context) error {
+	resp, err := d.httpGet(ctx, uriGetNotify)
+	if err != nil {
+		return err
+	}
+	d.Notify = &Notify{}
+	vals, err := d.parseResponse(resp)
+	if err != nil {
+		return fmt.Errorf("GetNotify: %v", err)
+	}
+	return d.Notify.populate(vals)
+}
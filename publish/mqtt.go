@@ -0,0 +1,134 @@
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+
+	"github.com/yob/go-daikin"
+)
+
+// mqttSensors lists the per-unit metrics MQTTPublisher exposes, in the
+// order they're announced via discovery.
+var mqttSensors = []struct {
+	key  string
+	unit string
+}{
+	{"home_temp_celsius", "°C"},
+	{"outside_temp_celsius", "°C"},
+	{"humidity_percent", "%"},
+	{"power", ""},
+	{"setpoint_celsius", "°C"},
+	{"watt_hours_today", "Wh"},
+}
+
+// MQTTPublisher is a Publisher that emits Home Assistant-compatible MQTT
+// discovery messages and per-metric state topics for each unit.
+type MQTTPublisher struct {
+	client mqtt.Client
+	prefix string // discovery topic prefix, eg "homeassistant"
+
+	// discoveredMu guards discovered, since Publish is called concurrently
+	// for each unit returned by Discover.
+	discoveredMu sync.Mutex
+	discovered   map[string]bool
+}
+
+// NewMQTTPublisher creates an MQTTPublisher that publishes on client,
+// using prefix as the Home Assistant discovery topic prefix.
+func NewMQTTPublisher(client mqtt.Client, prefix string) *MQTTPublisher {
+	return &MQTTPublisher{
+		client:     client,
+		prefix:     prefix,
+		discovered: map[string]bool{},
+	}
+}
+
+// Publish announces d via Home Assistant discovery the first time it's
+// seen, then publishes its current readings to their state topics.
+func (p *MQTTPublisher) Publish(ctx context.Context, d *daikin.Daikin, r daikin.Reading) error {
+	p.discoveredMu.Lock()
+	seen := p.discovered[d.MAC]
+	p.discoveredMu.Unlock()
+
+	if !seen {
+		if err := p.publishDiscovery(d); err != nil {
+			return err
+		}
+		p.discoveredMu.Lock()
+		p.discovered[d.MAC] = true
+		p.discoveredMu.Unlock()
+	}
+	return p.publishState(d, r)
+}
+
+type haDiscoveryConfig struct {
+	Name              string       `json:"name"`
+	StateTopic        string       `json:"state_topic"`
+	UnitOfMeasurement string       `json:"unit_of_measurement,omitempty"`
+	UniqueID          string       `json:"unique_id"`
+	Device            haDeviceInfo `json:"device"`
+}
+
+type haDeviceInfo struct {
+	Identifiers []string `json:"identifiers"`
+	Name        string   `json:"name"`
+}
+
+func (p *MQTTPublisher) publishDiscovery(d *daikin.Daikin) error {
+	device := haDeviceInfo{Identifiers: []string{d.MAC}, Name: d.Name.String()}
+	for _, s := range mqttSensors {
+		cfg := haDiscoveryConfig{
+			Name:              fmt.Sprintf("%s %s", d.Name.String(), s.key),
+			StateTopic:        p.stateTopic(d, s.key),
+			UnitOfMeasurement: s.unit,
+			UniqueID:          fmt.Sprintf("daikin_%s_%s", d.MAC, s.key),
+			Device:            device,
+		}
+		payload, err := json.Marshal(cfg)
+		if err != nil {
+			return fmt.Errorf("publish: mqtt: marshal discovery for %s: %v", s.key, err)
+		}
+		topic := fmt.Sprintf("%s/sensor/daikin_%s/%s/config", p.prefix, d.MAC, s.key)
+		if token := p.client.Publish(topic, 0, true, payload); token.Wait() && token.Error() != nil {
+			return fmt.Errorf("publish: mqtt: publish discovery for %s: %v", s.key, token.Error())
+		}
+	}
+	return nil
+}
+
+func (p *MQTTPublisher) stateTopic(d *daikin.Daikin, key string) string {
+	return fmt.Sprintf("%s/sensor/daikin_%s/%s/state", p.prefix, d.MAC, key)
+}
+
+func (p *MQTTPublisher) publishState(d *daikin.Daikin, r daikin.Reading) error {
+	values := map[string]string{}
+	if r.SensorInfo != nil {
+		values["home_temp_celsius"] = r.SensorInfo.HomeTemperature.String()
+		values["outside_temp_celsius"] = r.SensorInfo.OutsideTemperature.String()
+		values["humidity_percent"] = r.SensorInfo.Humidity.String()
+	}
+	if r.ControlInfo != nil {
+		values["power"] = strconv.Itoa(int(r.ControlInfo.Power))
+		values["setpoint_celsius"] = r.ControlInfo.Temperature.String()
+	}
+	if r.WeekPower != nil {
+		values["watt_hours_today"] = r.WeekPower.TodayWattHours.String()
+	}
+
+	for _, s := range mqttSensors {
+		v, ok := values[s.key]
+		if !ok {
+			continue
+		}
+		topic := p.stateTopic(d, s.key)
+		if token := p.client.Publish(topic, 0, false, v); token.Wait() && token.Error() != nil {
+			return fmt.Errorf("publish: mqtt: publish state for %s: %v", s.key, token.Error())
+		}
+	}
+	return nil
+}
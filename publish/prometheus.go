@@ -0,0 +1,83 @@
+package publish
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/yob/go-daikin"
+)
+
+// PrometheusCollector is a Publisher that exposes the most recent Reading
+// for each unit as Prometheus gauges, labelled by unit name and MAC.
+type PrometheusCollector struct {
+	reg *prometheus.Registry
+
+	homeTemp    *prometheus.GaugeVec
+	outsideTemp *prometheus.GaugeVec
+	humidity    *prometheus.GaugeVec
+	power       *prometheus.GaugeVec
+	setpoint    *prometheus.GaugeVec
+	wattHours   *prometheus.GaugeVec
+}
+
+// NewPrometheusCollector creates a PrometheusCollector with its own
+// registry, ready to be served with Handler.
+func NewPrometheusCollector() *PrometheusCollector {
+	labels := []string{"name", "mac"}
+	c := &PrometheusCollector{
+		reg: prometheus.NewRegistry(),
+		homeTemp: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "daikin_home_temp_celsius",
+			Help: "Interior temperature reported by the unit, in Celsius.",
+		}, labels),
+		outsideTemp: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "daikin_outside_temp_celsius",
+			Help: "Exterior temperature reported by the unit, in Celsius.",
+		}, labels),
+		humidity: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "daikin_humidity_percent",
+			Help: "Interior humidity reported by the unit, as a percentage.",
+		}, labels),
+		power: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "daikin_power",
+			Help: "Power status of the unit (0=off, 1=on).",
+		}, labels),
+		setpoint: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "daikin_setpoint_celsius",
+			Help: "Configured set temperature, in Celsius.",
+		}, labels),
+		wattHours: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "daikin_watt_hours_today",
+			Help: "Energy used by the unit so far today, in watt hours.",
+		}, labels),
+	}
+	c.reg.MustRegister(c.homeTemp, c.outsideTemp, c.humidity, c.power, c.setpoint, c.wattHours)
+	return c
+}
+
+// Publish updates the gauges for d with the values in r.
+func (c *PrometheusCollector) Publish(ctx context.Context, d *daikin.Daikin, r daikin.Reading) error {
+	labels := prometheus.Labels{"name": d.Name.String(), "mac": d.MAC}
+	if r.SensorInfo != nil {
+		c.homeTemp.With(labels).Set(float64(r.SensorInfo.HomeTemperature))
+		c.outsideTemp.With(labels).Set(float64(r.SensorInfo.OutsideTemperature))
+		c.humidity.With(labels).Set(float64(r.SensorInfo.Humidity))
+	}
+	if r.ControlInfo != nil {
+		c.power.With(labels).Set(float64(r.ControlInfo.Power))
+		c.setpoint.With(labels).Set(float64(r.ControlInfo.Temperature))
+	}
+	if r.WeekPower != nil {
+		c.wattHours.With(labels).Set(float64(r.WeekPower.TodayWattHours))
+	}
+	return nil
+}
+
+// Handler returns an http.Handler serving this collector's gauges in the
+// Prometheus exposition format, ready to mount at eg "/metrics".
+func (c *PrometheusCollector) Handler() http.Handler {
+	return promhttp.HandlerFor(c.reg, promhttp.HandlerOpts{})
+}
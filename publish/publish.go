@@ -0,0 +1,47 @@
+// Package publish provides reusable sinks for daikin.Reading values
+// produced by (*daikin.Daikin).Stream, so that every integration doesn't
+// have to write its own exporter glue.
+package publish
+
+import (
+	"context"
+
+	"github.com/yob/go-daikin"
+)
+
+// Publisher sends one Reading for a unit to an external system, such as a
+// metrics backend or message broker.
+type Publisher interface {
+	Publish(ctx context.Context, d *daikin.Daikin, r daikin.Reading) error
+}
+
+// Run forwards readings from a Stream to pub until readings is closed (ie
+// until the context passed to Stream is cancelled). Errors from errs, and
+// any error returned by pub.Publish, are passed to onError if it is
+// non-nil. onError is only called from the goroutine running Run, and
+// never after Run returns.
+func Run(ctx context.Context, pub Publisher, d *daikin.Daikin, readings <-chan daikin.Reading, errs <-chan error, onError func(error)) {
+	for {
+		select {
+		case r, ok := <-readings:
+			if !ok {
+				return
+			}
+			if err := pub.Publish(ctx, d, r); err != nil && onError != nil {
+				onError(err)
+			}
+		case err, ok := <-errs:
+			if !ok {
+				// Stream closes readings and errs together, so this
+				// means there's nothing left to forward; return
+				// (rather than continue) to avoid spinning on the
+				// closed channel until select happens to pick the
+				// readings case instead.
+				return
+			}
+			if onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package daikin
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Block is a single time-of-day entry in a DaySchedule: from Start until
+// End, the unit should be set to Power/Mode/Temperature.
+type Block struct {
+	// Start is the time of day this block begins, as a duration since
+	// midnight.
+	Start time.Duration
+	// End is the time of day this block ends, as a duration since
+	// midnight.
+	End time.Duration
+	// Power is the power status to apply during this block.
+	Power Power
+	// Mode is the operating mode to apply during this block.
+	Mode Mode
+	// Temperature is the set temperature to apply during this block.
+	Temperature Temperature
+}
+
+// DaySchedule is the list of Blocks active on a single day, sorted by
+// Start.
+type DaySchedule []Block
+
+// validate checks that a DaySchedule's blocks are sorted and don't
+// overlap. If requireFullCoverage is set, it also requires the blocks to
+// cover the entire day with no gaps.
+func (ds DaySchedule) validate(requireFullCoverage bool) error {
+	for i, b := range ds {
+		if b.End <= b.Start {
+			return fmt.Errorf("block %d: end %s is not after start %s", i, b.End, b.Start)
+		}
+		if i > 0 {
+			prev := ds[i-1]
+			if b.Start < prev.Start {
+				return fmt.Errorf("block %d starts before block %d: blocks must be sorted", i, i-1)
+			}
+			if b.Start < prev.End {
+				return fmt.Errorf("block %d overlaps block %d", i, i-1)
+			}
+		}
+	}
+	if !requireFullCoverage {
+		return nil
+	}
+	if len(ds) == 0 {
+		return fmt.Errorf("day has no blocks, but full day coverage is required")
+	}
+	if ds[0].Start != 0 {
+		return fmt.Errorf("day does not start at midnight")
+	}
+	if ds[len(ds)-1].End != 24*time.Hour {
+		return fmt.Errorf("day does not end at midnight")
+	}
+	for i := 1; i < len(ds); i++ {
+		if ds[i].Start != ds[i-1].End {
+			return fmt.Errorf("gap between block %d and block %d", i-1, i)
+		}
+	}
+	return nil
+}
+
+// Schedule is a unit's full weekly program: one DaySchedule per day of the
+// week, indexed as time.Sunday..time.Saturday.
+type Schedule [7]DaySchedule
+
+// Validate checks that every day's blocks are sorted and non-overlapping.
+// If requireFullCoverage is set, each day's blocks must also cover the
+// full 24 hours with no gaps, which some devices require before accepting
+// a schedule.
+func (s *Schedule) Validate(requireFullCoverage bool) error {
+	for day, ds := range s {
+		if err := ds.validate(requireFullCoverage); err != nil {
+			return fmt.Errorf("%s: %v", time.Weekday(day), err)
+		}
+	}
+	return nil
+}
+
+// Field widths (in hex characters) of the fixed-width per-block encoding
+// used by encode/decodeBlock below.
+//
+// This layout has not been confirmed against the "f=" field a real unit
+// returns from /aircon/get_program or accepts via /aircon/set_program; it
+// is this package's own guess at a bitmap-per-day encoding, not a
+// documented or reverse-engineered device format. Treat
+// ExperimentalGetSchedule/ExperimentalSetSchedule accordingly.
+const (
+	blockMinuteWidth = 3 // minutes since midnight, 0-1440
+	blockEnumWidth   = 1 // Power or Mode value
+	blockTempWidth   = 3 // temperature * 10, as an integer
+	blockHexLen      = 2*blockMinuteWidth + 2*blockEnumWidth + blockTempWidth
+)
+
+// encode renders a Block as the fixed-width hex code used by this package's
+// (unconfirmed) schedule encoding: start and end minutes, power, mode, and
+// temperature (in tenths of a degree) packed back to back.
+func (b Block) encode() string {
+	return fmt.Sprintf("%0*x%0*x%0*x%0*x%0*x",
+		blockMinuteWidth, int(b.Start/time.Minute),
+		blockMinuteWidth, int(b.End/time.Minute),
+		blockEnumWidth, int(b.Power),
+		blockEnumWidth, int(b.Mode),
+		blockTempWidth, int(b.Temperature*10),
+	)
+}
+
+func decodeBlock(s string) (Block, error) {
+	if len(s) != blockHexLen {
+		return Block{}, fmt.Errorf("invalid block %q: want %d hex chars, got %d", s, blockHexLen, len(s))
+	}
+	i := 0
+	readHex := func(width int) (int64, error) {
+		v, err := strconv.ParseInt(s[i:i+width], 16, 32)
+		i += width
+		return v, err
+	}
+
+	start, err := readHex(blockMinuteWidth)
+	if err != nil {
+		return Block{}, fmt.Errorf("invalid block %q: start: %v", s, err)
+	}
+	end, err := readHex(blockMinuteWidth)
+	if err != nil {
+		return Block{}, fmt.Errorf("invalid block %q: end: %v", s, err)
+	}
+	pow, err := readHex(blockEnumWidth)
+	if err != nil {
+		return Block{}, fmt.Errorf("invalid block %q: pow: %v", s, err)
+	}
+	mode, err := readHex(blockEnumWidth)
+	if err != nil {
+		return Block{}, fmt.Errorf("invalid block %q: mode: %v", s, err)
+	}
+	temp, err := readHex(blockTempWidth)
+	if err != nil {
+		return Block{}, fmt.Errorf("invalid block %q: temp: %v", s, err)
+	}
+
+	return Block{
+		Start:       time.Duration(start) * time.Minute,
+		End:         time.Duration(end) * time.Minute,
+		Power:       Power(pow),
+		Mode:        Mode(mode),
+		Temperature: Temperature(float64(temp) / 10),
+	}, nil
+}
+
+func decodeDaySchedule(s string) (DaySchedule, error) {
+	if len(s)%blockHexLen != 0 {
+		return nil, fmt.Errorf("invalid day %q: length %d is not a multiple of %d", s, len(s), blockHexLen)
+	}
+	n := len(s) / blockHexLen
+	ds := make(DaySchedule, 0, n)
+	for i := 0; i < n; i++ {
+		b, err := decodeBlock(s[i*blockHexLen : (i+1)*blockHexLen])
+		if err != nil {
+			return nil, err
+		}
+		ds = append(ds, b)
+	}
+	return ds, nil
+}
+
+// encode renders a Schedule in this package's own schedule encoding: one
+// hex blob per day, separated by commas, in time.Sunday..time.Saturday
+// order. See the blockHexLen comment: this has not been verified against a
+// real unit's "f=" wire format.
+func (s *Schedule) encode() string {
+	days := make([]string, len(s))
+	for i, ds := range s {
+		var b strings.Builder
+		for _, blk := range ds {
+			b.WriteString(blk.encode())
+		}
+		days[i] = b.String()
+	}
+	return strings.Join(days, ",")
+}
+
+func decodeSchedule(raw string) (*Schedule, error) {
+	days := strings.Split(raw, ",")
+	if len(days) != 7 {
+		return nil, fmt.Errorf("expected 7 days in schedule, got %d", len(days))
+	}
+	var s Schedule
+	for i, day := range days {
+		ds, err := decodeDaySchedule(day)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", time.Weekday(i), err)
+		}
+		s[i] = ds
+	}
+	return &s, nil
+}
+
+// ExperimentalGetSchedule fetches the unit's weekly schedule program and
+// decodes it using this package's own, unconfirmed schedule encoding (see
+// the blockHexLen comment). It may fail to decode a real unit's
+// Program.Raw, or silently misinterpret it; verify against your own unit
+// before relying on the result.
+func (d *Daikin) ExperimentalGetSchedule() (*Schedule, error) {
+	return d.ExperimentalGetScheduleContext(context.Background())
+}
+
+// ExperimentalGetScheduleContext is ExperimentalGetSchedule with a
+// caller-supplied context.
+func (d *Daikin) ExperimentalGetScheduleContext(ctx context.Context) (*Schedule, error) {
+	if err := d.GetProgramContext(ctx); err != nil {
+		return nil, fmt.Errorf("ExperimentalGetSchedule: %v", err)
+	}
+	s, err := decodeSchedule(d.Program.Raw)
+	if err != nil {
+		return nil, fmt.Errorf("ExperimentalGetSchedule: %v", err)
+	}
+	return s, nil
+}
+
+// ExperimentalSetSchedule validates s and pushes it to the unit encoded in
+// this package's own, unconfirmed schedule encoding (see the blockHexLen
+// comment). The unit may reject the resulting payload, or accept it and
+// apply something other than the intended schedule; verify against your
+// own unit before relying on this.
+func (d *Daikin) ExperimentalSetSchedule(s *Schedule) error {
+	return d.ExperimentalSetScheduleContext(context.Background(), s)
+}
+
+// ExperimentalSetScheduleContext is ExperimentalSetSchedule with a
+// caller-supplied context.
+func (d *Daikin) ExperimentalSetScheduleContext(ctx context.Context, s *Schedule) error {
+	if err := s.Validate(false); err != nil {
+		return fmt.Errorf("ExperimentalSetSchedule: %v", err)
+	}
+
+	qStr := url.Values{}
+	qStr.Set("f", s.encode())
+	resp, err := d.httpGet(ctx, fmt.Sprintf("%s?%s", uriSetProgram, qStr.Encode()))
+	if err != nil {
+		return err
+	}
+	vals, err := d.parseResponse(resp)
+	if err != nil {
+		return err
+	}
+	if v := vals["ret"]; v != returnOk {
+		return fmt.Errorf("device returned error ret=%s", v)
+	}
+	return nil
+}
+
+// SetScheduleTimer pushes the unit's schedule-linked timer state.
+func (d *Daikin) SetScheduleTimer(s *ScheduleTimer) error {
+	return d.SetScheduleTimerContext(context.Background(), s)
+}
+
+// SetScheduleTimerContext is SetScheduleTimer with a caller-supplied
+// context.
+func (d *Daikin) SetScheduleTimerContext(ctx context.Context, s *ScheduleTimer) error {
+	qStr := url.Values{}
+	if s.Enabled {
+		qStr.Set("en_scdltimer", "1")
+	} else {
+		qStr.Set("en_scdltimer", "0")
+	}
+	qStr.Set("tt", s.Raw)
+
+	resp, err := d.httpGet(ctx, fmt.Sprintf("%s?%s", uriSetScdlTimer, qStr.Encode()))
+	if err != nil {
+		return err
+	}
+	vals, err := d.parseResponse(resp)
+	if err != nil {
+		return err
+	}
+	if v := vals["ret"]; v != returnOk {
+		return fmt.Errorf("device returned error ret=%s", v)
+	}
+	return nil
+}
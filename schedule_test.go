@@ -0,0 +1,92 @@
+package daikin
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func exampleSchedule() *Schedule {
+	var s Schedule
+	s[time.Sunday] = DaySchedule{
+		{Start: 0, End: 6 * time.Hour, Power: PowerOff, Mode: ModeAuto, Temperature: 20},
+		{Start: 6 * time.Hour, End: 24 * time.Hour, Power: PowerOn, Mode: ModeHeat, Temperature: 21.5},
+	}
+	s[time.Monday] = DaySchedule{
+		{Start: 7 * time.Hour, End: 9 * time.Hour, Power: PowerOn, Mode: ModeCool, Temperature: 23},
+	}
+	// Tuesday through Saturday are left with no blocks.
+	return &s
+}
+
+func TestScheduleEncodeDecodeRoundTrip(t *testing.T) {
+	want := exampleSchedule()
+
+	raw := want.encode()
+	got, err := decodeSchedule(raw)
+	if err != nil {
+		t.Fatalf("decodeSchedule(%q) returned error: %v", raw, err)
+	}
+
+	for day := range want {
+		if len(got[day]) != len(want[day]) {
+			t.Fatalf("day %s: got %d blocks, want %d", time.Weekday(day), len(got[day]), len(want[day]))
+		}
+		for i := range want[day] {
+			if got[day][i] != want[day][i] {
+				t.Errorf("day %s block %d: got %+v, want %+v", time.Weekday(day), i, got[day][i], want[day][i])
+			}
+		}
+	}
+}
+
+func TestScheduleEncodeDaysAreCommaSeparated(t *testing.T) {
+	raw := exampleSchedule().encode()
+	if got := strings.Count(raw, ","); got != 6 {
+		t.Errorf("encode() produced %d commas, want 6 (one per day boundary)", got)
+	}
+}
+
+func TestDecodeScheduleWrongDayCount(t *testing.T) {
+	if _, err := decodeSchedule("a,b,c"); err == nil {
+		t.Error("decodeSchedule with 3 days: got nil error, want an error")
+	}
+}
+
+func TestScheduleValidateRejectsOverlap(t *testing.T) {
+	var s Schedule
+	s[time.Sunday] = DaySchedule{
+		{Start: 0, End: 2 * time.Hour, Power: PowerOn, Mode: ModeCool, Temperature: 22},
+		{Start: time.Hour, End: 3 * time.Hour, Power: PowerOn, Mode: ModeCool, Temperature: 22},
+	}
+	if err := s.Validate(false); err == nil {
+		t.Error("Validate with overlapping blocks: got nil error, want an error")
+	}
+}
+
+func TestScheduleValidateRejectsUnsorted(t *testing.T) {
+	var s Schedule
+	s[time.Sunday] = DaySchedule{
+		{Start: 2 * time.Hour, End: 3 * time.Hour, Power: PowerOn, Mode: ModeCool, Temperature: 22},
+		{Start: 0, End: time.Hour, Power: PowerOn, Mode: ModeCool, Temperature: 22},
+	}
+	if err := s.Validate(false); err == nil {
+		t.Error("Validate with unsorted blocks: got nil error, want an error")
+	}
+}
+
+func TestDayScheduleValidateFullCoverage(t *testing.T) {
+	ds := DaySchedule{
+		{Start: 0, End: 12 * time.Hour, Power: PowerOn, Mode: ModeCool, Temperature: 22},
+	}
+	if err := ds.validate(true); err == nil {
+		t.Error("validate(true) with a gap before midnight: got nil error, want an error")
+	}
+
+	ds = append(ds, Block{
+		Start: 12 * time.Hour, End: 24 * time.Hour, Power: PowerOff, Mode: ModeAuto, Temperature: 18,
+	})
+	if err := ds.validate(true); err != nil {
+		t.Errorf("validate(true) with full day coverage: got error %v, want nil", err)
+	}
+}
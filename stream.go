@@ -0,0 +1,210 @@
+package daikin
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// minStreamInterval is the smallest poll interval Stream will honour.
+// time.Ticker panics on a non-positive interval, so intervals at or below
+// zero are clamped up to this instead of propagating that panic to callers.
+const minStreamInterval = 1 * time.Second
+
+// Reading is a single point-in-time snapshot of a Daikin unit's state, as
+// produced by Stream.
+type Reading struct {
+	// Time is when this reading was taken.
+	Time time.Time
+	// ControlInfo is the control settings at Time.
+	ControlInfo *ControlInfo
+	// SensorInfo is the sensor values at Time.
+	SensorInfo *SensorInfo
+	// WeekPower is the past 7 days of power usage at Time.
+	WeekPower *WeekPower
+}
+
+// subscriber is one consumer of a streamer's readings.
+type subscriber struct {
+	readings chan Reading
+	errs     chan error
+	// done is closed when this subscriber's Stream ctx is cancelled, so a
+	// poll blocked sending to a subscriber that has stopped reading doesn't
+	// wedge the fan-out for every other subscriber.
+	done chan struct{}
+}
+
+// streamer runs a single poll loop on behalf of all of a Daikin's Stream
+// subscribers, so calling Stream more than once on the same unit doesn't
+// multiply the number of HTTP requests made to it.
+type streamer struct {
+	mu       sync.Mutex
+	subs     map[*subscriber]struct{}
+	interval time.Duration
+	cancel   context.CancelFunc
+	// resize signals the poll loop to re-read interval and reset its
+	// ticker; buffered so a shrinkInterval call never blocks on it.
+	resize chan struct{}
+}
+
+// Stream repeatedly polls GetControlInfo, GetSensorInfo and GetWeekPower on
+// the given interval, coalescing them into a Reading and pushing it to the
+// returned channel until ctx is cancelled. Transient errors are reported on
+// the returned error channel but don't stop polling. Both channels are
+// closed once ctx is done.
+//
+// Stream may be called more than once on the same *Daikin; all subscribers
+// share a single underlying poll loop via an internal fan-out, so the unit
+// is not polled any more often than the fastest subscriber's interval.
+// interval is clamped up to minStreamInterval.
+func (d *Daikin) Stream(ctx context.Context, interval time.Duration) (<-chan Reading, <-chan error) {
+	if interval < minStreamInterval {
+		interval = minStreamInterval
+	}
+
+	sub := &subscriber{
+		readings: make(chan Reading),
+		errs:     make(chan error),
+		done:     make(chan struct{}),
+	}
+
+	d.streamMu.Lock()
+	if d.streaming == nil {
+		d.streaming = d.startStreamer(interval)
+	} else {
+		d.streaming.shrinkInterval(interval)
+	}
+	s := d.streaming
+	d.streamMu.Unlock()
+
+	s.mu.Lock()
+	s.subs[sub] = struct{}{}
+	s.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		d.unsubscribe(s, sub)
+	}()
+
+	return sub.readings, sub.errs
+}
+
+func (d *Daikin) startStreamer(interval time.Duration) *streamer {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &streamer{
+		subs:     map[*subscriber]struct{}{},
+		interval: interval,
+		cancel:   cancel,
+		resize:   make(chan struct{}, 1),
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		poll := func() {
+			reading, err := d.poll()
+
+			s.mu.Lock()
+			subs := make([]*subscriber, 0, len(s.subs))
+			for sub := range s.subs {
+				subs = append(subs, sub)
+			}
+			s.mu.Unlock()
+
+			// Sends happen without s.mu held, so a subscriber that has
+			// stopped reading only blocks its own delivery (via sub.done)
+			// rather than wedging every other subscriber and unsubscribe.
+			for _, sub := range subs {
+				if err != nil {
+					select {
+					case sub.errs <- err:
+					case <-sub.done:
+					case <-ctx.Done():
+					}
+					continue
+				}
+				select {
+				case sub.readings <- reading:
+				case <-sub.done:
+				case <-ctx.Done():
+				}
+			}
+		}
+
+		poll()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.resize:
+				s.mu.Lock()
+				next := s.interval
+				s.mu.Unlock()
+				ticker.Reset(next)
+			case <-ticker.C:
+				poll()
+			}
+		}
+	}()
+
+	return s
+}
+
+// shrinkInterval reduces the streamer's poll interval if interval is faster
+// than the one currently in effect, so Stream honours the fastest
+// subscriber rather than whichever subscriber happened to start the loop.
+func (s *streamer) shrinkInterval(interval time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if interval >= s.interval {
+		return
+	}
+	s.interval = interval
+	select {
+	case s.resize <- struct{}{}:
+	default:
+	}
+}
+
+func (d *Daikin) unsubscribe(s *streamer, sub *subscriber) {
+	close(sub.done)
+
+	s.mu.Lock()
+	delete(s.subs, sub)
+	empty := len(s.subs) == 0
+	s.mu.Unlock()
+
+	close(sub.readings)
+	close(sub.errs)
+
+	if empty {
+		d.streamMu.Lock()
+		if d.streaming == s {
+			d.streaming = nil
+		}
+		d.streamMu.Unlock()
+		s.cancel()
+	}
+}
+
+// poll gathers one Reading, reusing d's existing Get* methods so errors are
+// reported in the same form callers already expect.
+func (d *Daikin) poll() (Reading, error) {
+	if err := d.GetControlInfo(); err != nil {
+		return Reading{}, fmt.Errorf("stream: %v", err)
+	}
+	if err := d.GetSensorInfo(); err != nil {
+		return Reading{}, fmt.Errorf("stream: %v", err)
+	}
+	if err := d.GetWeekPower(); err != nil {
+		return Reading{}, fmt.Errorf("stream: %v", err)
+	}
+	return Reading{
+		Time:        time.Now(),
+		ControlInfo: d.ControlInfo,
+		SensorInfo:  d.SensorInfo,
+		WeekPower:   d.WeekPower,
+	}, nil
+}